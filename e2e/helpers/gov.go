@@ -2,42 +2,285 @@ package helpers
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"testing"
 
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
+// dialStakingQueryClient dials c's gRPC endpoint and returns a staking
+// query client plus the connection's Close func, so the handful of
+// validator-eligibility callers in this file don't each repeat the
+// grpc.NewClient/defer boilerplate.
+func dialStakingQueryClient(c *cosmos.CosmosChain) (stakingtypes.QueryClient, func() error, error) {
+	grpcConn, err := grpc.NewClient(c.GetHostGRPCAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return stakingtypes.NewQueryClient(grpcConn), grpcConn.Close, nil
+}
+
 // Modified from ictest
+//
+// Validators that are jailed or no longer bonded are skipped rather than
+// voted with: a jailed/unbonded operator can't sign a valid vote tx, and
+// letting that tx fail inside the errgroup used to mask real voting errors
+// behind an unrelated "validator jailed" failure.
 func VoteOnProposalAllValidators(ctx context.Context, c *cosmos.CosmosChain, proposalID string, vote string) error {
+	stakingClient, closeConn, err := dialStakingQueryClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
 	var eg errgroup.Group
 	valKey := "validator"
 	for _, n := range c.Nodes() {
-		if n.Validator {
-			n := n
-			eg.Go(func() error {
-				// gas-adjustment was using 1.3 default instead of the setup's 2.0+ for some reason.
-				// return n.VoteOnProposal(ctx, valKey, proposalID, vote)
-
-				_, err := n.ExecTx(ctx, valKey,
-					"gov", "vote",
-					proposalID, vote, "--gas", "auto", "--gas-adjustment", "2.0",
-				)
-				return err
-			})
+		if !n.Validator {
+			continue
 		}
+		n := n
+		eg.Go(func() error {
+			eligible, err := validatorNodeCanVote(ctx, stakingClient, n, valKey)
+			if err != nil {
+				return err
+			}
+			if !eligible {
+				return nil
+			}
+
+			// gas-adjustment was using 1.3 default instead of the setup's 2.0+ for some reason.
+			// return n.VoteOnProposal(ctx, valKey, proposalID, vote)
+
+			_, err = n.ExecTx(ctx, valKey,
+				"gov", "vote",
+				proposalID, vote, "--gas", "auto", "--gas-adjustment", "2.0",
+			)
+			return err
+		})
 	}
 	return eg.Wait()
 }
 
+// validatorCanVote reports whether operatorAddr's validator is bonded and
+// not jailed. Tombstoning implies jailed in the staking module, so checking
+// Jailed covers it without a separate slashing query. A validator that's
+// been fully removed from the staking module (as opposed to just jailed or
+// unbonded, a real outcome of poa's remove-validator admin action) reports
+// NotFound rather than a jailed/unbonded record; that's treated as
+// ineligible too, not as a query failure, so a removed validator doesn't
+// fail the caller's errgroup.
+func validatorCanVote(ctx context.Context, client stakingtypes.QueryClient, operatorAddr string) (bool, error) {
+	res, err := client.Validator(ctx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: operatorAddr})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if res.Validator.Jailed {
+		return false, nil
+	}
+	return res.Validator.Status == stakingtypes.Bonded, nil
+}
+
+// validatorNodeCanVote is validatorCanVote for a chain node directly: it
+// resolves n's operator address and checks eligibility, the two steps
+// every vote-casting helper in this file needs before issuing an ExecTx.
+func validatorNodeCanVote(ctx context.Context, client stakingtypes.QueryClient, n *cosmos.ChainNode, valKey string) (bool, error) {
+	operatorAddr, err := n.KeyBech32(ctx, valKey, "val")
+	if err != nil {
+		return false, err
+	}
+	return validatorCanVote(ctx, client, operatorAddr)
+}
+
+// parseProposalID parses the string proposal ID used throughout these
+// helpers (matching the CLI's arg format) into the uint64 ID gov's gRPC
+// queries expect.
+func parseProposalID(proposalID string) (uint64, error) {
+	return strconv.ParseUint(proposalID, 10, 64)
+}
+
+// queryProposal fetches the gov v1 proposal so callers can inspect fields
+// (status, expedited, tally) that the CLI's plain text output doesn't expose.
+func queryProposal(ctx context.Context, c *cosmos.CosmosChain, proposalID string) (*govv1.Proposal, error) {
+	id, err := parseProposalID(proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcConn, err := grpc.NewClient(c.GetHostGRPCAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer grpcConn.Close()
+
+	res, err := govv1.NewQueryClient(grpcConn).Proposal(ctx, &govv1.QueryProposalRequest{ProposalId: id})
+	if err != nil {
+		return nil, err
+	}
+	return res.Proposal, nil
+}
+
 func ValidatorVote(t *testing.T, ctx context.Context, chain *cosmos.CosmosChain, proposalID string, searchHeightDelta uint64) {
-	err := VoteOnProposalAllValidators(ctx, chain, proposalID, cosmos.ProposalVoteYes)
+	ValidatorVoteExpedited(t, ctx, chain, proposalID, searchHeightDelta, false)
+}
+
+// ValidatorVoteExpedited is like ValidatorVote, but understands the gov v1
+// expedited proposal flow: expedited proposals are expected to resolve to
+// ProposalStatusPassed within a shorter voting window, and if the expedited
+// threshold isn't met they fall back to a standard tally rather than failing
+// outright (gov v1 semantics), so we keep polling for a final status instead
+// of bailing out on the first non-passed result.
+func ValidatorVoteExpedited(t *testing.T, ctx context.Context, chain *cosmos.CosmosChain, proposalID string, searchHeightDelta uint64, expedited bool) {
+	proposal, err := queryProposal(ctx, chain, proposalID)
+	require.NoError(t, err, "failed to query proposal")
+	require.Equal(t, expedited, proposal.Expedited, "proposal expedited flag did not match expectation")
+
+	err = VoteOnProposalAllValidators(ctx, chain, proposalID, cosmos.ProposalVoteYes)
 	require.NoError(t, err, "failed to vote on proposal")
 
 	height, err := chain.Height(ctx)
 	require.NoError(t, err, "failed to get height")
 
-	_, err = cosmos.PollForProposalStatus(ctx, chain, height, height+searchHeightDelta, proposalID, cosmos.ProposalStatusPassed)
+	status, err := cosmos.PollForProposalStatus(ctx, chain, height, height+searchHeightDelta, proposalID, cosmos.ProposalStatusPassed)
+	if expedited && err != nil {
+		// Expedited threshold wasn't met in the shorter window; gov v1 falls
+		// back to the standard tally instead of rejecting the proposal, so
+		// give it the remainder of the standard voting period to resolve.
+		status, err = cosmos.PollForProposalStatus(ctx, chain, height, height+(searchHeightDelta*2), proposalID, cosmos.ProposalStatusPassed)
+	}
 	require.NoError(t, err, "proposal status did not change to passed in expected number of blocks")
+	require.Equal(t, cosmos.ProposalStatusPassed, status)
+}
+
+// WeightedVoteOption is a single (option, weight) pair for `tx gov
+// weighted-vote`, e.g. {VoteYes, "0.7"}. Weight is a decimal string as
+// accepted by the CLI, not a float, to avoid formatting surprises.
+type WeightedVoteOption struct {
+	Option VoteOption
+	Weight string
+}
+
+// weightedVoteArg renders weights into the "option=weight,option=weight"
+// form `tx gov weighted-vote` expects.
+func weightedVoteArg(weights []WeightedVoteOption) string {
+	parts := make([]string, len(weights))
+	for i, w := range weights {
+		parts[i] = string(w.Option) + "=" + w.Weight
+	}
+	return strings.Join(parts, ",")
+}
+
+// VoteWeightedOnProposalAllValidators is the weighted-vote sibling of
+// VoteOnProposalAllValidators: every validator casts the same split vote via
+// `tx gov weighted-vote`. Jailed/unbonded validators are skipped for the
+// same reason VoteOnProposalAllValidators skips them: they can't sign a
+// valid vote tx, and letting that tx fail inside the errgroup masks real
+// voting errors behind an unrelated "validator jailed" failure.
+func VoteWeightedOnProposalAllValidators(ctx context.Context, c *cosmos.CosmosChain, proposalID string, weights []WeightedVoteOption) error {
+	stakingClient, closeConn, err := dialStakingQueryClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	var eg errgroup.Group
+	valKey := "validator"
+	voteArg := weightedVoteArg(weights)
+	for _, n := range c.Nodes() {
+		if !n.Validator {
+			continue
+		}
+		n := n
+		eg.Go(func() error {
+			eligible, err := validatorNodeCanVote(ctx, stakingClient, n, valKey)
+			if err != nil {
+				return err
+			}
+			if !eligible {
+				return nil
+			}
+
+			_, err = n.ExecTx(ctx, valKey,
+				"gov", "weighted-vote",
+				proposalID, voteArg, "--gas", "auto", "--gas-adjustment", "2.0",
+			)
+			return err
+		})
+	}
+	return eg.Wait()
+}
+
+// VoteWeightedOnProposalMixed lets each validator cast a distinct weighted
+// vote, keyed by its index among validator nodes (in the order c.Nodes()
+// returns them). Validators without an entry in weights are left to
+// abstain from voting entirely, so tests can exercise partial or split
+// tallies against gov v1's weighted-vote threshold math. Jailed/unbonded
+// validators are skipped regardless of whether they have a weights entry,
+// matching VoteOnProposalAllValidators.
+func VoteWeightedOnProposalMixed(ctx context.Context, c *cosmos.CosmosChain, proposalID string, weights map[int][]WeightedVoteOption) error {
+	stakingClient, closeConn, err := dialStakingQueryClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	var eg errgroup.Group
+	valKey := "validator"
+	valIdx := 0
+	for _, n := range c.Nodes() {
+		if !n.Validator {
+			continue
+		}
+		w, ok := weights[valIdx]
+		valIdx++
+		if !ok {
+			continue
+		}
+		n := n
+		voteArg := weightedVoteArg(w)
+		eg.Go(func() error {
+			eligible, err := validatorNodeCanVote(ctx, stakingClient, n, valKey)
+			if err != nil {
+				return err
+			}
+			if !eligible {
+				return nil
+			}
+
+			_, err = n.ExecTx(ctx, valKey,
+				"gov", "weighted-vote",
+				proposalID, voteArg, "--gas", "auto", "--gas-adjustment", "2.0",
+			)
+			return err
+		})
+	}
+	return eg.Wait()
+}
+
+// ValidatorVoteMixed casts a mixed weighted vote per VoteWeightedOnProposalMixed
+// and asserts the proposal resolves to expectedStatus, letting tests assert
+// tally outcomes when validators split their votes across gov v1 weighted
+// options.
+func ValidatorVoteMixed(t *testing.T, ctx context.Context, chain *cosmos.CosmosChain, proposalID string, weights map[int][]WeightedVoteOption, searchHeightDelta uint64, expectedStatus cosmos.ProposalStatus) {
+	err := VoteWeightedOnProposalMixed(ctx, chain, proposalID, weights)
+	require.NoError(t, err, "failed to cast mixed weighted votes")
+
+	height, err := chain.Height(ctx)
+	require.NoError(t, err, "failed to get height")
+
+	status, err := cosmos.PollForProposalStatus(ctx, chain, height, height+searchHeightDelta, proposalID, expectedStatus)
+	require.NoError(t, err, "proposal status did not reach %s in expected number of blocks", expectedStatus)
+	require.Equal(t, expectedStatus, status)
 }
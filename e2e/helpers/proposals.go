@@ -0,0 +1,188 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"testing"
+
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ProposalSpec describes a single gov v1 proposal to submit via
+// SubmitAndPassProposals. Title must be unique within a batch, since it's
+// used to look the proposal back up on chain after submission.
+type ProposalSpec struct {
+	Title     string
+	Summary   string
+	Metadata  string
+	Deposit   string
+	Messages  []json.RawMessage
+	Expedited bool
+}
+
+// submitProposal writes spec out as a gov v1 proposal file on n's home
+// directory and submits it with `tx gov submit-proposal`, matching the CLI
+// flow the other helpers in this package use rather than constructing and
+// signing messages directly.
+func submitProposal(ctx context.Context, n *cosmos.ChainNode, keyName string, spec ProposalSpec) error {
+	content, err := json.Marshal(map[string]any{
+		"messages":  spec.Messages,
+		"metadata":  spec.Metadata,
+		"deposit":   spec.Deposit,
+		"title":     spec.Title,
+		"summary":   spec.Summary,
+		"expedited": spec.Expedited,
+	})
+	if err != nil {
+		return err
+	}
+
+	fileName := fmt.Sprintf("proposal_%s.json", spec.Title)
+	if err := n.WriteFile(ctx, content, fileName); err != nil {
+		return err
+	}
+
+	_, err = n.ExecTx(ctx, keyName,
+		"gov", "submit-proposal", path.Join(n.HomeDir(), fileName),
+		"--gas", "auto", "--gas-adjustment", "2.0",
+	)
+	return err
+}
+
+// findProposalIDByTitle looks up a just-submitted proposal's ID by title.
+// CLI submission doesn't hand back the assigned ID directly, so callers that
+// need it (e.g. to vote or poll for status) look it up this way instead.
+func findProposalIDByTitle(ctx context.Context, c *cosmos.CosmosChain, title string) (string, error) {
+	grpcConn, err := grpc.NewClient(c.GetHostGRPCAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return "", err
+	}
+	defer grpcConn.Close()
+
+	res, err := govv1.NewQueryClient(grpcConn).Proposals(ctx, &govv1.QueryProposalsRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	// Newest first, so a title reused from an earlier batch resolves to the
+	// just-submitted proposal rather than the old one.
+	for i := len(res.Proposals) - 1; i >= 0; i-- {
+		p := res.Proposals[i]
+		if p.Title == title {
+			return fmt.Sprintf("%d", p.Id), nil
+		}
+	}
+	return "", fmt.Errorf("no proposal found with title %q", title)
+}
+
+// SubmitAndPassProposals submits every spec and casts every vote
+// concurrently across validator wallets, but keeps each wallet's own
+// transactions in sequence: a given validator key only ever has one
+// broadcast in flight at a time, so its account sequence number never
+// races itself. Round-robining specs across validators without that
+// per-wallet ordering would still collide once there are more specs than
+// validators, so specs are bucketed per validator up front instead. This
+// replaces submitting/voting/polling proposals one at a time, which
+// serializes suites that exercise many admin actions (set-power,
+// add/remove validator, params updates) in sequence. Those same admin
+// actions can jail/unbond/remove a validator mid-suite, so both the submit
+// and vote phases skip a validator wallet that's ineligible to sign
+// (validatorCanVote in gov.go) rather than letting its ExecTx fail the
+// whole batch via errgroup; a spec whose assigned validator gets skipped on
+// the submit side simply never gets created, which surfaces downstream as
+// a clear "no proposal found" error from findProposalIDByTitle.
+func SubmitAndPassProposals(t *testing.T, ctx context.Context, chain *cosmos.CosmosChain, specs []ProposalSpec, searchHeightDelta uint64) []string {
+	var validators []*cosmos.ChainNode
+	for _, n := range chain.Nodes() {
+		if n.Validator {
+			validators = append(validators, n)
+		}
+	}
+	require.NotEmpty(t, validators, "chain has no validator nodes")
+
+	valKey := "validator"
+
+	stakingClient, closeConn, err := dialStakingQueryClient(chain)
+	require.NoError(t, err, "failed to dial chain grpc")
+	defer closeConn()
+
+	specsByValidator := make([][]ProposalSpec, len(validators))
+	for i, spec := range specs {
+		v := i % len(validators)
+		specsByValidator[v] = append(specsByValidator[v], spec)
+	}
+
+	var submitEg errgroup.Group
+	for i, n := range validators {
+		n, specs := n, specsByValidator[i]
+		submitEg.Go(func() error {
+			eligible, err := validatorNodeCanVote(ctx, stakingClient, n, valKey)
+			if err != nil {
+				return err
+			}
+			if !eligible {
+				return nil
+			}
+			for _, spec := range specs {
+				if err := submitProposal(ctx, n, valKey, spec); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	require.NoError(t, submitEg.Wait(), "failed to submit proposals")
+
+	proposalIDs := make([]string, len(specs))
+	for i, spec := range specs {
+		id, err := findProposalIDByTitle(ctx, chain, spec.Title)
+		require.NoError(t, err, "failed to look up proposal %q", spec.Title)
+		proposalIDs[i] = id
+	}
+
+	var voteEg errgroup.Group
+	for _, n := range validators {
+		n := n
+		voteEg.Go(func() error {
+			eligible, err := validatorNodeCanVote(ctx, stakingClient, n, valKey)
+			if err != nil {
+				return err
+			}
+			if !eligible {
+				return nil
+			}
+			for _, id := range proposalIDs {
+				if _, err := n.ExecTx(ctx, valKey,
+					"gov", "vote", id, cosmos.ProposalVoteYes,
+					"--gas", "auto", "--gas-adjustment", "2.0",
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	require.NoError(t, voteEg.Wait(), "failed to vote on proposals")
+
+	height, err := chain.Height(ctx)
+	require.NoError(t, err, "failed to get height")
+
+	var pollEg errgroup.Group
+	for _, id := range proposalIDs {
+		id := id
+		pollEg.Go(func() error {
+			_, err := cosmos.PollForProposalStatus(ctx, chain, height, height+searchHeightDelta, id, cosmos.ProposalStatusPassed)
+			return err
+		})
+	}
+	require.NoError(t, pollEg.Wait(), "one or more proposals did not pass in expected number of blocks")
+
+	return proposalIDs
+}
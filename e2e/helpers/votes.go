@@ -0,0 +1,165 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// VoteOption mirrors gov's vote options, plus a sentinel for validators that
+// never cast a vote, so callers can distinguish "voted no" from "didn't vote
+// at all".
+type VoteOption string
+
+const (
+	VoteYes        VoteOption = "yes"
+	VoteAbstain    VoteOption = "abstain"
+	VoteNo         VoteOption = "no"
+	VoteNoWithVeto VoteOption = "no_with_veto"
+	VoteDidNotVote VoteOption = "did_not_vote"
+)
+
+func voteOptionFromV1(opt govv1.VoteOption) VoteOption {
+	switch opt {
+	case govv1.OptionYes:
+		return VoteYes
+	case govv1.OptionAbstain:
+		return VoteAbstain
+	case govv1.OptionNo:
+		return VoteNo
+	case govv1.OptionNoWithVeto:
+		return VoteNoWithVeto
+	default:
+		return VoteDidNotVote
+	}
+}
+
+// WatchValidatorVotes queries gov for how each bonded validator voted on
+// proposalID, keyed by validator operator address. Validator nodes that are
+// jailed, unbonded or removed (per validatorCanVote) are left out of the
+// map. A validator's account
+// address is derived the same way the gov module does: the raw bytes of its
+// operator (valoper) address, re-prefixed as an account address. Validators
+// that never submitted a vote are recorded as VoteDidNotVote instead of
+// being silently dropped, so callers can catch cases where a subset of
+// validators failed to vote (key mismatch, gas errors, etc.) rather than
+// relying on the all-or-nothing failure of errgroup.Wait() in
+// VoteOnProposalAllValidators.
+func WatchValidatorVotes(t *testing.T, ctx context.Context, chain *cosmos.CosmosChain, proposalID string) map[string]VoteOption {
+	id, err := parseProposalID(proposalID)
+	require.NoError(t, err, "failed to parse proposal id")
+
+	grpcConn, err := grpc.NewClient(chain.GetHostGRPCAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err, "failed to dial chain grpc")
+	defer grpcConn.Close()
+
+	govClient := govv1.NewQueryClient(grpcConn)
+	legacyGovClient := govv1beta1.NewQueryClient(grpcConn)
+	stakingClient := stakingtypes.NewQueryClient(grpcConn)
+
+	votes := make(map[string]VoteOption)
+	for _, v := range chain.Validators {
+		operatorAddr, err := v.KeyBech32(ctx, "validator", "val")
+		require.NoError(t, err, "failed to get validator operator address")
+
+		bonded, err := validatorCanVote(ctx, stakingClient, operatorAddr)
+		require.NoError(t, err, "failed to check validator %s status", operatorAddr)
+		if !bonded {
+			continue
+		}
+
+		valAddr, err := sdk.ValAddressFromBech32(operatorAddr)
+		require.NoError(t, err, "failed to parse validator operator address")
+		voter := sdk.AccAddress(valAddr).String()
+
+		res, err := govClient.Vote(ctx, &govv1.QueryVoteRequest{ProposalId: id, Voter: voter})
+		if err == nil && len(res.Vote.Options) > 0 {
+			votes[operatorAddr] = voteOptionFromV1(res.Vote.Options[0].Option)
+			continue
+		}
+
+		legacyRes, err := legacyGovClient.Vote(ctx, &govv1beta1.QueryVoteRequest{ProposalId: id, Voter: voter})
+		if err == nil && len(legacyRes.Vote.Options) > 0 {
+			votes[operatorAddr] = voteOptionFromV1(govv1.VoteOption(legacyRes.Vote.Options[0].Option))
+			continue
+		}
+
+		votes[operatorAddr] = VoteDidNotVote
+	}
+
+	return votes
+}
+
+// AssertAllValidatorsVoted fails the test if any validator eligible to vote
+// (bonded and not jailed, per validatorCanVote) has a recorded vote of
+// VoteDidNotVote, logging the full per-validator breakdown either way so
+// failures are easy to diagnose from CI output. Jailed/unbonded validators
+// are excluded from the check: VoteOnProposalAllValidators deliberately
+// skips them rather than voting with them, so requiring a vote from one
+// here would fail tests for the exact scenario that skip exists for.
+func AssertAllValidatorsVoted(t *testing.T, ctx context.Context, chain *cosmos.CosmosChain, proposalID string) map[string]VoteOption {
+	votes := WatchValidatorVotes(t, ctx, chain, proposalID)
+
+	stakingClient, closeConn, err := dialStakingQueryClient(chain)
+	require.NoError(t, err, "failed to dial chain grpc")
+	defer closeConn()
+
+	counts := make(map[VoteOption]int)
+	for operatorAddr, opt := range votes {
+		eligible, err := validatorCanVote(ctx, stakingClient, operatorAddr)
+		require.NoError(t, err, "failed to check validator %s eligibility", operatorAddr)
+		if !eligible {
+			t.Logf("validator %s is jailed/unbonded, excluding from vote check", operatorAddr)
+			continue
+		}
+		counts[opt]++
+		t.Logf("validator %s voted %s", operatorAddr, opt)
+	}
+	t.Logf("vote tally for proposal %s: %+v", proposalID, counts)
+
+	require.Zero(t, counts[VoteDidNotVote], "one or more validators did not vote on proposal %s", proposalID)
+	return votes
+}
+
+// ValidatorVoteExpectQuorum queries the current bonded validator set,
+// figures out what fraction of its voting power actually voted on
+// proposalID, and fails the test if that fraction is below
+// requiredVotingPower (e.g. 0.334 for a blocking-minority check, 0.5 for
+// majority). This matters for poa tests where admin actions can change the
+// active validator set mid-test, shrinking quorum out from under a
+// proposal that looked safe when it was submitted.
+func ValidatorVoteExpectQuorum(t *testing.T, ctx context.Context, chain *cosmos.CosmosChain, proposalID string, requiredVotingPower float64) {
+	grpcConn, err := grpc.NewClient(chain.GetHostGRPCAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err, "failed to dial chain grpc")
+	defer grpcConn.Close()
+
+	bonded, err := stakingtypes.NewQueryClient(grpcConn).Validators(ctx, &stakingtypes.QueryValidatorsRequest{
+		Status: stakingtypes.BondStatusBonded,
+	})
+	require.NoError(t, err, "failed to query bonded validators")
+	require.NotEmpty(t, bonded.Validators, "no bonded validators found")
+
+	votes := WatchValidatorVotes(t, ctx, chain, proposalID)
+
+	var totalPower, votedPower int64
+	for _, v := range bonded.Validators {
+		power := v.Tokens.Quo(sdk.DefaultPowerReduction).Int64()
+		totalPower += power
+		if opt, ok := votes[v.OperatorAddress]; ok && opt != VoteDidNotVote {
+			votedPower += power
+		}
+	}
+
+	actual := float64(votedPower) / float64(totalPower)
+	require.GreaterOrEqualf(t, actual, requiredVotingPower,
+		"only %.2f%% of bonded voting power voted on proposal %s, need %.2f%%",
+		actual*100, proposalID, requiredVotingPower*100)
+}